@@ -0,0 +1,24 @@
+package container // import "github.com/moby/moby/v2/api/server/router/container"
+
+import (
+	"context"
+
+	"github.com/moby/moby/api/types/container"
+)
+
+// stateBackend includes functions to implement to provide container state lifecycle functionality.
+type stateBackend interface {
+	ContainerCreate(ctx context.Context, config container.CreateRequest) (container.CreateResponse, error)
+	ContainerKill(name string, signal string) error
+	ContainerPause(name string) error
+	ContainerRename(oldName, newName string) error
+	ContainerResize(ctx context.Context, name string, height, width int) error
+	ContainerRestart(ctx context.Context, name string, options container.StopOptions) error
+	ContainerRm(name string, config *container.RemoveOptions) error
+	ContainerStart(ctx context.Context, name string, checkpoint string, checkpointDir string) error
+	ContainerStop(ctx context.Context, name string, options container.StopOptions) error
+	ContainerStopWait(ctx context.Context, name string, options container.StopOptions) (container.StopResult, error)
+	ContainerUnpause(name string) error
+	ContainerUpdate(name string, hostConfig *container.UpdateConfig) (container.UpdateResponse, error)
+	ContainerWait(ctx context.Context, name string, condition container.WaitCondition) (<-chan container.StateStatus, error)
+}
@@ -0,0 +1,84 @@
+package container // import "github.com/moby/moby/v2/api/server/router/container"
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/api/server/httputils"
+)
+
+// decodeStopOptions reads a container.StopOptions from the request. If the
+// request has a JSON body (used to carry an escalation ladder), it takes
+// precedence; otherwise the legacy `signal` and `t` query parameters are
+// used, preserving backward compatibility with older clients.
+func decodeStopOptions(r *http.Request) (container.StopOptions, error) {
+	var options container.StopOptions
+
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+			return options, err
+		}
+		return options, nil
+	}
+
+	if sig := r.Form.Get("signal"); sig != "" {
+		options.Signal = sig
+	}
+	if t := r.Form.Get("t"); t != "" {
+		valSeconds, err := strconv.Atoi(t)
+		if err != nil {
+			return options, err
+		}
+		options.Timeout = &valSeconds
+	}
+	return options, nil
+}
+
+func (s *containerRouter) postContainersStop(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	options, err := decodeStopOptions(r)
+	if err != nil {
+		return err
+	}
+
+	name := vars["name"]
+	if httputils.BoolValue(r, "wait") {
+		result, err := s.backend.ContainerStopWait(ctx, name, options)
+		if err != nil {
+			return err
+		}
+		return httputils.WriteJSON(w, http.StatusOK, result)
+	}
+
+	if err := s.backend.ContainerStop(ctx, name, options); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *containerRouter) postContainersRestart(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	// The restart route accepts the same `signal`/`t` query parameters and
+	// JSON escalation-ladder body as the stop route, since restarting a
+	// container stops it first using the same options.
+	options, err := decodeStopOptions(r)
+	if err != nil {
+		return err
+	}
+
+	if err := s.backend.ContainerRestart(ctx, vars["name"], options); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,57 @@
+package container // import "github.com/moby/moby/api/types/container"
+
+import "time"
+
+// StopStep describes a single step of a staged shutdown: the signal to
+// send and how long to wait for the container to exit before moving on
+// to the next step.
+type StopStep struct {
+	// Signal is the signal to send for this step, e.g. "SIGTERM". If
+	// empty, the container's configured stop signal (or the engine
+	// default) is used.
+	Signal string
+
+	// Timeout is the number of seconds to wait for the container to
+	// exit after Signal is sent before proceeding to the next step.
+	// A negative value means wait forever. A nil value inherits the
+	// image/engine default timeout.
+	Timeout *int
+}
+
+// StopOptions holds the options to stop or restart a container.
+type StopOptions struct {
+	// Signal is the signal to send to the container to request it to
+	// stop. It is equivalent to a one-step Escalation and is kept for
+	// backward compatibility; if Escalation is non-empty, Escalation
+	// takes precedence.
+	Signal string
+
+	// Timeout is the timeout (in seconds) to wait for the container to
+	// stop before killing it. It is equivalent to a one-step Escalation
+	// and is kept for backward compatibility; if Escalation is
+	// non-empty, Escalation takes precedence.
+	Timeout *int
+
+	// Escalation is an ordered list of signal/timeout steps to send to
+	// the container. Each step's signal is sent and, if the container
+	// has not exited after that step's timeout, the next step is
+	// attempted. If the ladder is exhausted without the container
+	// exiting, the container is forcefully killed, also when the last
+	// step already sent SIGKILL.
+	Escalation []StopStep
+
+	// KillAfter is an additional timeout (in seconds) applied after the
+	// last Escalation step if that step's signal is not SIGKILL. If set,
+	// SIGKILL is sent once KillAfter elapses following the last step
+	// instead of immediately once the ladder is exhausted.
+	KillAfter *int
+}
+
+// StopResult reports how a container exited after a stop-and-wait request.
+type StopResult struct {
+	ExitCode         int
+	OOMKilled        bool
+	KilledBySignal   string
+	GracefulShutdown bool
+	Duration         time.Duration
+}
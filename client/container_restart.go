@@ -0,0 +1,81 @@
+package client // import "github.com/moby/moby/client"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strconv"
+
+	"github.com/moby/moby/api/types/container"
+)
+
+// ContainerRestartOptions holds the options to restart a container,
+// mirroring ContainerStopOptions for the stop phase of the restart.
+type ContainerRestartOptions struct {
+	// Signal is the signal to send to the container to request it to
+	// stop before it is started again. If empty, the container's
+	// configured stop signal (or the engine default) is used.
+	Signal string
+
+	// Timeout is the timeout (in seconds) to wait for the container to
+	// stop before killing it. A negative value means wait forever, a
+	// nil value inherits the image/engine default.
+	Timeout *int
+
+	// Escalation is an ordered list of signal/timeout steps to send
+	// during the stop phase of the restart. See container.StopStep for
+	// details. When non-empty, it is sent as a JSON request body and
+	// takes precedence over Signal/Timeout.
+	Escalation []StopStep
+
+	// KillAfter is an additional timeout (in seconds) applied after the
+	// last Escalation step. See container.StopOptions.KillAfter.
+	KillAfter *int
+}
+
+// ContainerRestart stops and starts a container again.
+// The stop phase works as in ContainerStop: the daemon waits up to the
+// timeout for the container to stop before killing it, then starts it.
+func (cli *Client) ContainerRestart(ctx context.Context, containerID string, options ContainerRestartOptions) error {
+	query, body, err := encodeRestartOptions(options)
+	if err != nil {
+		return err
+	}
+
+	resp, err := cli.post(ctx, "/containers/"+containerID+"/restart", query, body, nil)
+	ensureReaderClosed(resp)
+	return err
+}
+
+// encodeRestartOptions builds the query parameters and, when an escalation
+// ladder is present, the JSON request body used to restart a container. The
+// `signal` and `t` query parameters are always sent for backward
+// compatibility; a non-empty Escalation is additionally sent as a JSON body
+// that newer daemons use in preference to the query parameters.
+func encodeRestartOptions(options ContainerRestartOptions) (url.Values, io.Reader, error) {
+	query := url.Values{}
+	if options.Timeout != nil {
+		query.Set("t", strconv.Itoa(*options.Timeout))
+	}
+	if options.Signal != "" {
+		query.Set("signal", options.Signal)
+	}
+
+	if len(options.Escalation) == 0 {
+		return query, nil, nil
+	}
+
+	body := container.StopOptions{
+		Signal:     options.Signal,
+		Timeout:    options.Timeout,
+		Escalation: options.Escalation,
+		KillAfter:  options.KillAfter,
+	}
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return query, bytes.NewReader(buf), nil
+}
@@ -0,0 +1,105 @@
+package client // import "github.com/moby/moby/client"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strconv"
+
+	"github.com/moby/moby/api/types/container"
+)
+
+// StopStep is a single step of a container stop escalation ladder. It is
+// an alias of container.StopStep so that callers can build escalation
+// ladders without importing the container types package directly.
+type StopStep = container.StopStep
+
+// ContainerStopOptions holds the options to stop a container. Restarting a
+// container takes ContainerRestartOptions instead.
+type ContainerStopOptions struct {
+	// Signal is the signal to send to the container to request it to
+	// stop. It is equivalent to a one-step Escalation and is kept for
+	// backward compatibility; if Escalation is non-empty, Escalation
+	// takes precedence.
+	Signal string
+
+	// Timeout is the timeout (in seconds) to wait for the container to
+	// stop before killing it. It is equivalent to a one-step Escalation
+	// and is kept for backward compatibility; if Escalation is
+	// non-empty, Escalation takes precedence.
+	Timeout *int
+
+	// Escalation is an ordered list of signal/timeout steps to send to
+	// the container. See container.StopStep for details on each step.
+	// When non-empty, it is sent as a JSON request body and takes
+	// precedence over Signal/Timeout.
+	Escalation []StopStep
+
+	// KillAfter is an additional timeout (in seconds) applied after the
+	// last Escalation step. See container.StopOptions.KillAfter.
+	KillAfter *int
+}
+
+// ContainerStopResponse holds the result of a ContainerStop call. It is
+// currently empty (the stop route responds with no body), but is returned
+// alongside the error for symmetry with the rest of the options-struct
+// client methods, and to leave room to surface more detail in the future
+// without a signature change.
+type ContainerStopResponse struct{}
+
+// ContainerStop stops a container. In case the container fails to stop
+// gracefully within a time frame specified by the timeout argument,
+// it is forcefully terminated (killed).
+//
+// If the timeout is nil, the container's StopTimeout value is used, if set,
+// otherwise the engine default. A negative timeout value can be specified,
+// meaning no timeout, i.e. no forceful termination is performed.
+//
+// If options.Escalation is set, it describes a staged shutdown: each step's
+// signal is sent and, if the container is still running once that step's
+// timeout elapses, the next step is attempted. See container.StopStep for
+// details. Escalation takes precedence over Signal/Timeout when both are set.
+func (cli *Client) ContainerStop(ctx context.Context, containerID string, options ContainerStopOptions) (ContainerStopResponse, error) {
+	query, body, err := encodeStopOptions(options)
+	if err != nil {
+		return ContainerStopResponse{}, err
+	}
+
+	resp, err := cli.post(ctx, "/containers/"+containerID+"/stop", query, body, nil)
+	ensureReaderClosed(resp)
+	return ContainerStopResponse{}, err
+}
+
+// encodeStopOptions builds the query parameters and, when an escalation
+// ladder is present, the JSON request body used to stop a container. The
+// legacy Signal/Timeout fields are always sent as query parameters for
+// backward compatibility with older daemons; a non-empty Escalation is
+// additionally sent as a JSON body that newer daemons use in preference to
+// the query parameters.
+func encodeStopOptions(options ContainerStopOptions) (url.Values, io.Reader, error) {
+	query := url.Values{}
+	if options.Timeout != nil {
+		query.Set("t", strconv.Itoa(*options.Timeout))
+	}
+	if options.Signal != "" {
+		query.Set("signal", options.Signal)
+	}
+
+	if len(options.Escalation) == 0 {
+		return query, nil, nil
+	}
+
+	body := container.StopOptions{
+		Signal:     options.Signal,
+		Timeout:    options.Timeout,
+		Escalation: options.Escalation,
+		KillAfter:  options.KillAfter,
+	}
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return query, bytes.NewReader(buf), nil
+}
@@ -0,0 +1,48 @@
+package client // import "github.com/moby/moby/client"
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// ContainerStopResult reports how a container exited after a
+// ContainerStopWait call.
+type ContainerStopResult struct {
+	ExitCode         int
+	OOMKilled        bool
+	KilledBySignal   string
+	GracefulShutdown bool
+	Duration         time.Duration
+}
+
+// ContainerStopWait stops a container and blocks until it has fully exited,
+// atomically, returning the exit state observed right after the stop.
+//
+// Unlike calling ContainerStop followed by polling and then inspecting the
+// container, this is race-free with respect to restart policies: restart
+// policies are suppressed for the duration of the call, so the returned
+// result reflects the container instance that was stopped, not a
+// subsequently restarted instance.
+//
+// Canceling ctx aborts the wait for the exit result; it does not abort the
+// stop itself, which has already been requested by the time ctx is checked.
+func (cli *Client) ContainerStopWait(ctx context.Context, containerID string, options ContainerStopOptions) (ContainerStopResult, error) {
+	query, body, err := encodeStopOptions(options)
+	if err != nil {
+		return ContainerStopResult{}, err
+	}
+	query.Set("wait", "1")
+
+	resp, err := cli.post(ctx, "/containers/"+containerID+"/stop", query, body, nil)
+	defer ensureReaderClosed(resp)
+	if err != nil {
+		return ContainerStopResult{}, err
+	}
+
+	var result ContainerStopResult
+	if err := json.NewDecoder(resp.body).Decode(&result); err != nil {
+		return ContainerStopResult{}, err
+	}
+	return result, nil
+}
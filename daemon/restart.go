@@ -0,0 +1,40 @@
+package daemon // import "github.com/moby/moby/v2/daemon"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moby/moby/api/types/container"
+	containerpkg "github.com/moby/moby/v2/daemon/container"
+)
+
+// ContainerRestart stops and starts a container. It attempts to
+// gracefully stop the container within the given timeout, forcefully
+// stopping it if the timeout is exceeded.
+//
+// options mirrors container.StopOptions for the stop phase of the
+// restart: when options.Signal/Escalation is set, it overrides the
+// image's configured stop signal for that phase, the same way
+// ContainerStop does.
+func (daemon *Daemon) ContainerRestart(ctx context.Context, name string, options container.StopOptions) error {
+	cntr, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+	if err := daemon.containerRestart(ctx, cntr, options); err != nil {
+		return fmt.Errorf("cannot restart container: %s: %w", name, err)
+	}
+	return nil
+}
+
+// containerRestart stops the container per options, honoring any signal
+// or escalation override the caller supplied instead of only the image's
+// StopSignal, and then starts it again.
+func (daemon *Daemon) containerRestart(ctx context.Context, cntr *containerpkg.Container, options container.StopOptions) error {
+	if cntr.IsRunning() {
+		if _, err := daemon.containerStop(ctx, cntr, options); err != nil {
+			return err
+		}
+	}
+	return daemon.ContainerStart(ctx, cntr.ID, "", "")
+}
@@ -0,0 +1,143 @@
+package daemon // import "github.com/moby/moby/v2/daemon"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/containerd/log"
+	"github.com/moby/moby/api/types/container"
+	containerpkg "github.com/moby/moby/v2/daemon/container"
+	"github.com/pkg/errors"
+)
+
+// defaultStopTimeout is used when a stop step does not specify a timeout
+// and the container has no configured StopTimeout to fall back to.
+const defaultStopTimeout = 10 * time.Second
+
+// ContainerStop looks for the given container and stops it.
+// In case the container fails to stop gracefully within a time duration
+// specified by the timeout argument, in seconds, it is forcefully
+// terminated (killed).
+//
+// If options.Escalation is set, it describes a staged shutdown: each step's
+// signal is sent and, if the container is still running once that step's
+// timeout elapses, the next step is attempted. If the ladder is exhausted
+// without the container exiting, it is forcefully killed, also when the
+// last step's signal already was SIGKILL; if the last step's signal is not
+// SIGKILL, options.KillAfter defers that final kill.
+//
+// If the timeout is nil, the container's StopTimeout value is used, if set,
+// otherwise the engine default. A negative timeout value can be specified,
+// meaning no timeout, i.e. no forceful termination is performed.
+func (daemon *Daemon) ContainerStop(ctx context.Context, name string, options container.StopOptions) error {
+	cntr, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+	if !cntr.IsRunning() {
+		return containerNotModifiedError{}
+	}
+	if _, err := daemon.containerStop(ctx, cntr, options); err != nil {
+		return fmt.Errorf("cannot stop container: %s: %w", name, err)
+	}
+	return nil
+}
+
+// containerStop sends the container the configured escalation ladder of
+// signals, waiting between each step for the container to exit, and
+// forcefully kills it if the ladder is exhausted. It returns the signal
+// that was in flight when the container was observed to exit (or
+// "SIGKILL" if a forceful kill was needed to make it exit).
+func (daemon *Daemon) containerStop(ctx context.Context, cntr *containerpkg.Container, options container.StopOptions) (string, error) {
+	if !cntr.IsRunning() {
+		return "", nil
+	}
+
+	ladder := stopLadder(options)
+
+	var lastSignal string
+	for _, step := range ladder {
+		signal := step.Signal
+		if signal == "" {
+			signal = cntr.Config.StopSignal
+		}
+		if signal == "" {
+			signal = "SIGTERM"
+		}
+		lastSignal = signal
+
+		if err := daemon.killPossiblyDeadProcess(cntr, signal); err != nil {
+			log.G(ctx).WithError(err).WithField("container", cntr.ID).
+				WithField("signal", signal).Warn("error sending stop signal")
+		}
+
+		if step.Timeout != nil && *step.Timeout < 0 {
+			// No timeout: wait indefinitely for this step to take effect.
+			<-cntr.Wait(ctx, containerpkg.WaitConditionNotRunning)
+			return signal, nil
+		}
+
+		wait := defaultStopTimeout
+		if cntr.Config.StopTimeout != nil {
+			wait = time.Duration(*cntr.Config.StopTimeout) * time.Second
+		}
+		if step.Timeout != nil {
+			wait = time.Duration(*step.Timeout) * time.Second
+		}
+
+		if daemon.waitForExitOrTimeout(ctx, cntr, wait) {
+			return signal, nil
+		}
+	}
+
+	if options.KillAfter != nil && lastSignal != "SIGKILL" {
+		if *options.KillAfter < 0 {
+			<-cntr.Wait(ctx, containerpkg.WaitConditionNotRunning)
+			return lastSignal, nil
+		}
+		if daemon.waitForExitOrTimeout(ctx, cntr, time.Duration(*options.KillAfter)*time.Second) {
+			return lastSignal, nil
+		}
+	}
+
+	// The ladder (and any KillAfter grace period) is exhausted: force-kill
+	// and confirm the container actually exits, rather than assuming it did.
+	// This also covers the case where the last escalation step was already
+	// SIGKILL and the container still hadn't exited by its timeout.
+	if err := daemon.Kill(cntr); err != nil {
+		<-cntr.Wait(ctx, containerpkg.WaitConditionNotRunning)
+		return "SIGKILL", errors.Wrap(err, "failed to kill container after stop escalation was exhausted")
+	}
+
+	<-cntr.Wait(ctx, containerpkg.WaitConditionNotRunning)
+	return "SIGKILL", nil
+}
+
+// waitForExitOrTimeout blocks until either the container exits or the given
+// duration elapses, returning true if the container exited in time.
+func (daemon *Daemon) waitForExitOrTimeout(ctx context.Context, cntr *containerpkg.Container, timeout time.Duration) bool {
+	subCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	select {
+	case <-cntr.Wait(subCtx, containerpkg.WaitConditionNotRunning):
+		return true
+	case <-subCtx.Done():
+		return false
+	}
+}
+
+// stopLadder normalizes a container.StopOptions into an ordered list of
+// stop steps. If Escalation is set it is used as-is; otherwise the legacy
+// Signal/Timeout pair is treated as a one-step ladder. Per-step signal and
+// timeout defaulting (falling back to the container's configured stop
+// signal/timeout, or the engine defaults) is applied uniformly to every
+// step by containerStop, regardless of which branch produced the ladder.
+func stopLadder(options container.StopOptions) []container.StopStep {
+	if len(options.Escalation) > 0 {
+		return options.Escalation
+	}
+
+	return []container.StopStep{{Signal: options.Signal, Timeout: options.Timeout}}
+}
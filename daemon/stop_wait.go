@@ -0,0 +1,67 @@
+package daemon // import "github.com/moby/moby/v2/daemon"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moby/moby/api/types/container"
+	containerpkg "github.com/moby/moby/v2/daemon/container"
+)
+
+// ContainerStopWait looks for the given container, stops it per options,
+// and blocks until it has fully exited, returning the exit state of the
+// instance that was stopped.
+//
+// The container's HasBeenManuallyStopped flag is held for the duration of
+// the call so that, if the container is configured to restart, the restart
+// monitor skips applying the restart policy to this exit and the returned
+// result still reflects the stopped instance rather than whatever instance
+// the restart policy would otherwise bring up. The flag is cleared once the
+// call returns, so the restart policy (if any) resumes applying to later
+// exits.
+//
+// containerStop itself runs against a context decoupled from ctx, so that
+// canceling ctx only abandons the wait below for the exit result; it does
+// not reach into the escalation ladder and force it to skip ahead to
+// SIGKILL. ctx cancellation is observed solely by the final select.
+func (daemon *Daemon) ContainerStopWait(ctx context.Context, name string, options container.StopOptions) (container.StopResult, error) {
+	cntr, err := daemon.GetContainer(name)
+	if err != nil {
+		return container.StopResult{}, err
+	}
+
+	cntr.Lock()
+	cntr.HasBeenManuallyStopped = true
+	cntr.Unlock()
+	defer func() {
+		cntr.Lock()
+		cntr.HasBeenManuallyStopped = false
+		cntr.Unlock()
+	}()
+
+	start := daemon.now()
+	var killedBySignal string
+	if cntr.IsRunning() {
+		killedBySignal, err = daemon.containerStop(context.WithoutCancel(ctx), cntr, options)
+		if err != nil {
+			return container.StopResult{}, fmt.Errorf("cannot stop container: %s: %w", name, err)
+		}
+	} else {
+		killedBySignal = cntr.Config.StopSignal
+	}
+
+	select {
+	case <-cntr.Wait(ctx, containerpkg.WaitConditionNotRunning):
+	case <-ctx.Done():
+		return container.StopResult{}, ctx.Err()
+	}
+
+	state := cntr.State
+	return container.StopResult{
+		ExitCode:         state.ExitCode(),
+		OOMKilled:        state.OOMKilled,
+		KilledBySignal:   killedBySignal,
+		GracefulShutdown: !state.OOMKilled && killedBySignal != "SIGKILL",
+		Duration:         daemon.now().Sub(start),
+	}, nil
+}
@@ -1,13 +1,17 @@
 package container
 
 import (
+	"context"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/moby/moby/api/types/common"
 	containertypes "github.com/moby/moby/api/types/container"
+	mounttypes "github.com/moby/moby/api/types/mount"
 	"github.com/moby/moby/client"
 	"github.com/moby/moby/v2/integration/internal/container"
 	"github.com/moby/moby/v2/internal/testutil/request"
@@ -162,6 +166,37 @@ func TestStopContainerWithRestartPolicyAlways(t *testing.T) {
 	}
 }
 
+// TestRestartContainerWithSignalOverride checks that ContainerRestart honors
+// a caller-supplied stop signal instead of only the image's configured
+// StopSignal, by asserting that the overriding signal reached PID 1 during
+// the restart's stop phase.
+func TestRestartContainerWithSignalOverride(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType == "windows")
+
+	ctx := setupTest(t)
+	apiClient := testEnv.APIClient()
+
+	markerDir := t.TempDir()
+
+	id := container.Run(ctx, t, apiClient,
+		container.WithCmd("sh", "-c",
+			"trap 'touch /marker/hit' USR1; trap 'exit 0' TERM; while true; do sleep 1; done"),
+		container.WithMount(mounttypes.Mount{
+			Type:   mounttypes.TypeBind,
+			Source: markerDir,
+			Target: "/marker",
+		}),
+	)
+
+	err := apiClient.ContainerRestart(ctx, id, client.ContainerRestartOptions{Signal: "SIGUSR1"})
+	assert.NilError(t, err)
+
+	poll.WaitOn(t, container.IsInState(ctx, apiClient, id, containertypes.StateRunning))
+
+	_, err = os.Stat(filepath.Join(markerDir, "hit"))
+	assert.NilError(t, err)
+}
+
 // TestStopContainerWithTimeout checks that ContainerStop with
 // a timeout works as documented, i.e. in case of negative timeout
 // waiting is not limited (issue #35311).
@@ -208,9 +243,9 @@ func TestStopContainerWithTimeout(t *testing.T) {
 		},
 	}
 
-	var pollOpts []poll.SettingOp
+	waitCtxTimeout := 30 * time.Second
 	if isWindows {
-		pollOpts = append(pollOpts, poll.WithTimeout(StopContainerWindowsPollTimeout))
+		waitCtxTimeout = StopContainerWindowsPollTimeout
 	}
 
 	for _, tc := range testData {
@@ -219,14 +254,94 @@ func TestStopContainerWithTimeout(t *testing.T) {
 			// t.Parallel()
 			id := container.Run(ctx, t, apiClient, testCmd)
 
-			_, err := apiClient.ContainerStop(ctx, id, client.ContainerStopOptions{Timeout: &tc.timeout})
-			assert.NilError(t, err)
+			waitCtx, cancel := context.WithTimeout(ctx, waitCtxTimeout)
+			defer cancel()
 
-			poll.WaitOn(t, container.IsStopped(ctx, apiClient, id), pollOpts...)
-
-			inspect, err := apiClient.ContainerInspect(ctx, id, client.ContainerInspectOptions{})
+			result, err := apiClient.ContainerStopWait(waitCtx, id, client.ContainerStopOptions{Timeout: &tc.timeout})
 			assert.NilError(t, err)
-			assert.Check(t, is.Equal(inspect.Container.State.ExitCode, tc.expectedExitCode))
+			assert.Check(t, is.Equal(result.ExitCode, tc.expectedExitCode))
 		})
 	}
 }
+
+// TestStopContainerWithTimeoutAndRestartPolicy checks that ContainerStopWait
+// reports the exit code of the container instance it stopped, even when a
+// restart policy would otherwise bring up a new instance before the caller
+// gets a chance to inspect it.
+func TestStopContainerWithTimeoutAndRestartPolicy(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType == "windows")
+
+	ctx := setupTest(t)
+	apiClient := testEnv.APIClient()
+
+	id := container.Run(ctx, t, apiClient,
+		container.WithCmd("sh", "-c", "sleep 10 && exit 42"),
+		container.WithRestartPolicy(containertypes.RestartPolicyAlways),
+	)
+
+	waitCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	timeout := 20
+	result, err := apiClient.ContainerStopWait(waitCtx, id, client.ContainerStopOptions{Timeout: &timeout})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(result.ExitCode, 42))
+	assert.Check(t, result.GracefulShutdown)
+}
+
+// TestStopContainerEscalation checks that ContainerStop sends the signals in
+// options.Escalation in order, waiting each step's timeout for the container
+// to exit before moving on to the next step, and that it only forcefully
+// kills the container (exit code 137) once the ladder is exhausted.
+func TestStopContainerEscalation(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType == "windows")
+
+	ctx := setupTest(t)
+	apiClient := testEnv.APIClient()
+
+	// Ignores SIGTERM, but exits cleanly on SIGINT.
+	testCmd := container.WithCmd("sh", "-c",
+		"trap 'exit 0' INT; trap '' TERM; while true; do sleep 1; done")
+
+	t.Run("ladder step catches the signal it listens for", func(t *testing.T) {
+		id := container.Run(ctx, t, apiClient, testCmd)
+
+		shortTimeout := 1
+		_, err := apiClient.ContainerStop(ctx, id, client.ContainerStopOptions{
+			Escalation: []client.StopStep{
+				{Signal: "SIGTERM", Timeout: &shortTimeout},
+				{Signal: "SIGINT", Timeout: &shortTimeout},
+				{Signal: "SIGKILL", Timeout: &shortTimeout},
+			},
+		})
+		assert.NilError(t, err)
+
+		poll.WaitOn(t, container.IsStopped(ctx, apiClient, id))
+
+		inspect, err := apiClient.ContainerInspect(ctx, id, client.ContainerInspectOptions{})
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(inspect.Container.State.ExitCode, 0))
+	})
+
+	t.Run("ladder exhaustion forcefully kills the container", func(t *testing.T) {
+		// Ignores both SIGTERM and SIGINT.
+		ignoresBoth := container.WithCmd("sh", "-c",
+			"trap '' INT; trap '' TERM; while true; do sleep 1; done")
+		id := container.Run(ctx, t, apiClient, ignoresBoth)
+
+		shortTimeout := 1
+		_, err := apiClient.ContainerStop(ctx, id, client.ContainerStopOptions{
+			Escalation: []client.StopStep{
+				{Signal: "SIGTERM", Timeout: &shortTimeout},
+				{Signal: "SIGINT", Timeout: &shortTimeout},
+			},
+		})
+		assert.NilError(t, err)
+
+		poll.WaitOn(t, container.IsStopped(ctx, apiClient, id))
+
+		inspect, err := apiClient.ContainerInspect(ctx, id, client.ContainerInspectOptions{})
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(inspect.Container.State.ExitCode, 137))
+	})
+}